@@ -0,0 +1,142 @@
+package fft
+
+// Bluestein ...
+const Bluestein = `
+
+// fieldOrder is the characteristic of fr.Element (i.e. |Fr|), and
+// primitiveElement generates its full multiplicative group (of order
+// fieldOrder-1), not just the 2-adic subgroup rootOfUnity in NewDomain
+// belongs to. Both are needed by computeBluesteinRoot to find roots of
+// unity of orders that are not powers of two.
+var fieldOrder big.Int
+var primitiveElement fr.Element
+
+func init() {
+	{{if eq .Curve "BLS377"}}
+		fieldOrder.SetString("8444461749428370424248824938781546531375899335154063827935233455917409239041", 10)
+		primitiveElement.SetUint64(22)
+	{{else if eq .Curve "BLS381"}}
+		fieldOrder.SetString("52435875175126190479447740508185965837690552500527637822603658699938581184513", 10)
+		primitiveElement.SetUint64(7)
+	{{else if eq .Curve "BN256"}}
+		fieldOrder.SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+		primitiveElement.SetUint64(5)
+	{{else if eq .Curve "BW761"}}
+		fieldOrder.SetString("258664426012969094010652733694893533536393512754914660539884262666720468348340822774968888139573360124440321458177", 10)
+		primitiveElement.SetUint64(15)
+	{{end}}
+}
+
+// NewDomainAnySize returns a Domain that can transform a vector of exactly m
+// elements, for an m that is not necessarily a power of two. Internally it
+// still builds a power-of-two Domain (of cardinality N, the next power of
+// two >= 2m-1) to run the convolutions, but FFT/IFFT called on this Domain
+// operate on the caller-visible size m via Bluestein's chirp-Z transform
+// instead of on N. The chirp needs a root of unity of order 2m in fr, so
+// NewDomainAnySize panics when 2m does not divide fieldOrder-1.
+func NewDomainAnySize(m int) *Domain {
+
+	// N is the smallest power of two such that N >= 2m-1: large enough to
+	// hold the linear (non-cyclic) convolution of two length-m sequences
+	// without wraparound.
+	n := nextPowerOfTwo(uint(2*m - 1))
+	domain := NewDomain(int(n))
+	domain.bluesteinN = m
+
+	// w is a 2m-th root of unity so that k^2/2 is always well defined in the
+	// exponent, even when k^2 is odd; squaring w yields the usual m-th root.
+	var w fr.Element
+	if err := computeBluesteinRoot(&w, uint64(2*m)); err != nil {
+		panic(err)
+	}
+
+	domain.chirp = make([]fr.Element, m)
+	chirpInv := make([]fr.Element, m)
+	var exp fr.Element
+	for k := 0; k < m; k++ {
+		// exp = w^(k^2), computed directly for each k
+		exp.Exp(w, new(big.Int).SetUint64(uint64(k*k)))
+		domain.chirp[k] = exp
+		chirpInv[k].Inverse(&exp)
+	}
+
+	// pre-compute the FFT of the chirp filter once: every subsequent call to
+	// fftAnySize reuses domain.chirpFFT as-is. The filter is the chirp
+	// sequence indexed by negative AND positive lag (c_{-k} = c_k, the same
+	// symmetry that lets Bluestein's convolution be computed cyclically), so
+	// besides the positive lags padded[0..m-1] we also wrap the negative
+	// lags around to padded[n-k] for k=1..m-1.
+	padded := make([]fr.Element, n)
+	copy(padded, chirpInv)
+	for k := 1; k < m; k++ {
+		padded[n-k] = chirpInv[k]
+	}
+	domain.plainFFT(padded, false)
+	domain.chirpFFT = padded
+
+	return domain
+}
+
+// computeBluesteinRoot sets w to a root of unity of order exactly ord. A
+// root of order ord only exists if ord divides fieldOrder-1 (the order of
+// the field's full multiplicative group): when ord happens to be a power of
+// two this is just a member of the 2-adic tower used by NewDomain, but for
+// the general, non-power-of-two ord Bluestein's algorithm actually needs, we
+// raise primitiveElement -- a generator of the whole multiplicative group,
+// not just its 2-adic subgroup -- to (fieldOrder-1)/ord.
+func computeBluesteinRoot(w *fr.Element, ord uint64) error {
+	var modMinusOne, bOrd big.Int
+	bOrd.SetUint64(ord)
+	modMinusOne.Sub(&fieldOrder, big.NewInt(1))
+
+	var r big.Int
+	r.Mod(&modMinusOne, &bOrd)
+	if r.Sign() != 0 {
+		return fmt.Errorf("fft: no root of unity of order %d in this field", ord)
+	}
+
+	var expo big.Int
+	expo.Div(&modMinusOne, &bOrd)
+	w.Exp(primitiveElement, &expo)
+	return nil
+}
+
+// fftAnySize runs the Bluestein chirp-Z transform of a, a slice of exactly
+// domain.bluesteinN elements: a_k is first scaled by the chirp w^(k^2/2),
+// the result is cyclically convolved (via the underlying power-of-two FFT)
+// with the conjugate chirp, and the convolution is scaled back by the chirp
+// a second time. The inverse transform runs the same forward path on a with
+// its indices negated mod m, then scales the result by 1/m.
+func (domain *Domain) fftAnySize(a []fr.Element, inverse bool) {
+	m := domain.bluesteinN
+
+	if inverse {
+		for k := 1; k < m-k; k++ {
+			a[k], a[m-k] = a[m-k], a[k]
+		}
+	}
+
+	b := make([]fr.Element, domain.Cardinality)
+	for k := 0; k < m; k++ {
+		b[k].Mul(&a[k], &domain.chirp[k])
+	}
+
+	domain.plainFFT(b, false)
+	for i := 0; i < len(b); i++ {
+		b[i].Mul(&b[i], &domain.chirpFFT[i])
+	}
+	domain.plainFFT(b, true)
+
+	for k := 0; k < m; k++ {
+		if inverse {
+			var invCardinality fr.Element
+			invCardinality.SetUint64(uint64(m)).Inverse(&invCardinality)
+			a[k].Mul(&b[k], &domain.chirp[k])
+			a[k].Mul(&a[k], &invCardinality)
+		} else {
+			a[k].Mul(&b[k], &domain.chirp[k])
+		}
+	}
+}
+
+`
@@ -0,0 +1,84 @@
+package fft
+
+// BluesteinTest ...
+const BluesteinTest = `
+
+import (
+	"math/big"
+	"testing"
+
+	{{ template "import_curve" . }}
+)
+
+func TestNewDomainAnySizeRoundTrip(t *testing.T) {
+	sizes := []int{3, 5, 6, 7, 9, 12}
+	for _, m := range sizes {
+		domain := NewDomainAnySize(m)
+
+		a := make([]fr.Element, m)
+		for i := range a {
+			a[i].SetUint64(uint64(i + 1))
+		}
+
+		b := make([]fr.Element, m)
+		copy(b, a)
+		domain.FFT(b, false)
+		domain.FFT(b, true)
+
+		for i := range a {
+			if !a[i].Equal(&b[i]) {
+				t.Fatalf("m=%d: FFT/IFFT round trip failed at index %d", m, i)
+			}
+		}
+	}
+}
+
+func TestNewDomainAnySizeMatchesNaiveDFT(t *testing.T) {
+	sizes := []int{3, 5, 6, 7, 9}
+	for _, m := range sizes {
+		var w fr.Element
+		if err := computeBluesteinRoot(&w, uint64(m)); err != nil {
+			t.Skipf("m=%d: %v", m, err)
+			continue
+		}
+
+		a := make([]fr.Element, m)
+		for i := range a {
+			a[i].SetUint64(uint64(i + 1))
+		}
+
+		want := naiveDFT(a, w)
+
+		domain := NewDomainAnySize(m)
+		got := make([]fr.Element, m)
+		copy(got, a)
+		domain.FFT(got, false)
+
+		for i := range want {
+			if !want[i].Equal(&got[i]) {
+				t.Fatalf("m=%d: naive DFT and NewDomainAnySize disagree at index %d", m, i)
+			}
+		}
+	}
+}
+
+// naiveDFT computes the O(m^2) discrete Fourier transform of a using w as
+// the m-th root of unity, for comparison against NewDomainAnySize's
+// Bluestein path.
+func naiveDFT(a []fr.Element, w fr.Element) []fr.Element {
+	m := len(a)
+	out := make([]fr.Element, m)
+	for k := 0; k < m; k++ {
+		var sum fr.Element
+		for n := 0; n < m; n++ {
+			var wPow, term fr.Element
+			wPow.Exp(w, new(big.Int).SetUint64(uint64((k*n)%m)))
+			term.Mul(&a[n], &wPow)
+			sum.Add(&sum, &term)
+		}
+		out[k] = sum
+	}
+	return out
+}
+
+`
@@ -0,0 +1,77 @@
+package fft
+
+// Coset ...
+const Coset = `
+
+// FFTCoset evaluates a, seen as the coefficients of a polynomial, on
+// shift*<g> instead of <g>, where <g> is the subgroup of order
+// domain.Cardinality generated by domain.Generator and shift is an
+// arbitrary, caller-supplied coset generator. Unlike ExpTable1, which
+// hardcodes GeneratorSqRt as the coset offset, this lets PLONK/Marlin-style
+// provers pick their own coset without recomputing the domain.
+func (domain *Domain) FFTCoset(a []fr.Element, shift fr.Element) {
+	scalePowers(a, shift)
+	domain.FFT(a, false)
+}
+
+// IFFTCoset is the inverse of FFTCoset: it interpolates a, seen as
+// evaluations on shift*<g>, back to coefficient form.
+func (domain *Domain) IFFTCoset(a []fr.Element, shift fr.Element) {
+	domain.FFT(a, true)
+	var shiftInv fr.Element
+	shiftInv.Inverse(&shift)
+	scalePowers(a, shiftInv)
+}
+
+// scalePowers multiplies a[i] by shift^i in place.
+func scalePowers(a []fr.Element, shift fr.Element) {
+	var power fr.Element
+	power.SetOne()
+	for i := 0; i < len(a); i++ {
+		a[i].Mul(&a[i], &power)
+		power.Mul(&power, &shift)
+	}
+}
+
+// EvaluateVanishingPolynomial returns Z_H(tau) = tau^n - 1, the vanishing
+// polynomial of the subgroup H of order domain.Cardinality, evaluated at
+// tau.
+func (domain *Domain) EvaluateVanishingPolynomial(tau fr.Element) fr.Element {
+	var res fr.Element
+	res.Exp(tau, new(big.Int).SetUint64(uint64(domain.Cardinality)))
+	var one fr.Element
+	one.SetOne()
+	res.Sub(&res, &one)
+	return res
+}
+
+// DivideByVanishingPolyOnCoset divides evals, the evaluations of a polynomial
+// on shift*<g>, by Z_H(shift*x) point-wise, where Z_H is the vanishing
+// polynomial of the subgroup H of order domain.Cardinality. evals is
+// modified in place.
+func (domain *Domain) DivideByVanishingPolyOnCoset(evals []fr.Element, shift fr.Element) {
+	var zShift fr.Element
+	zShift.Exp(shift, new(big.Int).SetUint64(uint64(domain.Cardinality)))
+	var one fr.Element
+	one.SetOne()
+	zShift.Sub(&zShift, &one)
+
+	var zShiftInv fr.Element
+	zShiftInv.Inverse(&zShift)
+
+	for i := 0; i < len(evals); i++ {
+		evals[i].Mul(&evals[i], &zShiftInv)
+	}
+}
+
+// SelectorPolynomial returns the Lagrange basis polynomial L_i of the
+// subgroup H of order domain.Cardinality generated by domain.Generator, in
+// coefficient form: L_i(g^i) = 1 and L_i(g^j) = 0 for j != i.
+func (domain *Domain) SelectorPolynomial(i int) []fr.Element {
+	l := make([]fr.Element, domain.Cardinality)
+	l[i].SetOne()
+	domain.FFT(l, true)
+	return l
+}
+
+`
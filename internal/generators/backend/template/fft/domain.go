@@ -4,6 +4,8 @@ package fft
 const Domain = `
 
 import (
+	"fmt"
+	"io"
 	"math/big"
 	"math/bits"
 	"runtime"
@@ -24,7 +26,9 @@ type Domain struct {
 	CardinalityInv   fr.Element
 
 
-	// TODO -- the following pre-computed slices need not to be serialized, they can be re-computed
+	// the following pre-computed slices need not to be serialized, they are
+	// re-computed from the fields above on first use after WriteTo/ReadFrom
+	// -- see precomputeOnce
 
 	// Twiddles factor for the FFT using Generator for each stage of the recursive FFT
 	Twiddles 		 [][]fr.Element
@@ -49,6 +53,20 @@ type Domain struct {
 	// ExpTable2[2] = fftDomain.GeneratorSqRtInv^2 * fftDomain.CardinalityInv
 	// note that the ExpTable2 is in bitReversed order
 	ExpTable2 []fr.Element
+
+	// precomputeOnce guards the lazy rebuild of Twiddles, TwiddlesInv,
+	// ExpTable1 and ExpTable2 after ReadFrom, so a Domain read from disk
+	// only pays for preComputeTwiddles once, on its first use.
+	precomputeOnce sync.Once
+
+	// set when the domain is built with NewDomainAnySize; lets FFT/IFFT
+	// dispatch to the Bluestein chirp-Z path instead of the power-of-two one
+	bluesteinN int
+
+	// chirp and its FFT, pre-computed once by NewDomainAnySize so that every
+	// call to the chirp-Z transform only pays for the cyclic convolution
+	chirp    []fr.Element
+	chirpFFT []fr.Element
 }
 
 // NewDomain returns a subgroup with a power of 2 cardinality
@@ -57,22 +75,7 @@ type Domain struct {
 // all other values can be derived from x, GeneratorSqrt
 func NewDomain(m int) *Domain {
 
-	// generator of the largest 2-adic subgroup
-	var rootOfUnity fr.Element
-	{{if eq .Curve "BLS377"}}
-		rootOfUnity.SetString("8065159656716812877374967518403273466521432693661810619979959746626482506078")
-		const maxOrderRoot uint = 47
-	{{else if eq .Curve "BLS381"}}
-		rootOfUnity.SetString("10238227357739495823651030575849232062558860180284477541189508159991286009131")
-		const maxOrderRoot uint = 32
-	{{else if eq .Curve "BN256"}}
-		rootOfUnity.SetString("19103219067921713944291392827692070036145651957329286315305642004821462161904")
-		const maxOrderRoot uint = 28
-	{{else if eq .Curve "BW761"}}
-		rootOfUnity.SetString("32863578547254505029601261939868325669770508939375122462904745766352256812585773382134936404344547323199885654433")
-		const maxOrderRoot uint = 46
-	{{end}}
-	
+	rootOfUnity, maxOrderRoot := rootOfUnityTower()
 
 	subGroup := &Domain{}
 	x := nextPowerOfTwo(uint(m))
@@ -96,11 +99,18 @@ func NewDomain(m int) *Domain {
 	subGroup.CardinalityInv.SetUint64(uint64(x)).Inverse(&subGroup.CardinalityInv)
 
 	// twiddle factors
-	subGroup.preComputeTwiddles()
+	subGroup.precomputeOnce.Do(subGroup.preComputeTwiddles)
 
 	return subGroup
 }
 
+// ensurePrecomputed rebuilds Twiddles, TwiddlesInv, ExpTable1 and ExpTable2
+// if they are not already present, which is the case right after ReadFrom.
+// It is idempotent and safe to call before every use of those tables.
+func (d *Domain) ensurePrecomputed() {
+	d.precomputeOnce.Do(d.preComputeTwiddles)
+}
+
 func (d *Domain) preComputeTwiddles() {
 	// nb fft stages
 	nbStages := uint(bits.TrailingZeros(uint(d.Cardinality)))
@@ -186,6 +196,49 @@ func precomputeExpTableChunk(scale, w fr.Element, power uint64, table []fr.Eleme
 }
 
 
+// rootOfUnityTower returns a generator of the field's largest 2-adic
+// subgroup and that subgroup's order, 2^maxOrderRoot: the same tower
+// NewDomain derives GeneratorSqRt/Generator from. Factored out so any
+// caller needing a power-of-two-order element (NewDomain, generatorOfOrder)
+// shares the one per-curve literal instead of repeating it.
+func rootOfUnityTower() (fr.Element, uint) {
+	var rootOfUnity fr.Element
+	var maxOrderRoot uint
+	{{if eq .Curve "BLS377"}}
+		rootOfUnity.SetString("8065159656716812877374967518403273466521432693661810619979959746626482506078")
+		maxOrderRoot = 47
+	{{else if eq .Curve "BLS381"}}
+		rootOfUnity.SetString("10238227357739495823651030575849232062558860180284477541189508159991286009131")
+		maxOrderRoot = 32
+	{{else if eq .Curve "BN256"}}
+		rootOfUnity.SetString("19103219067921713944291392827692070036145651957329286315305642004821462161904")
+		maxOrderRoot = 28
+	{{else if eq .Curve "BW761"}}
+		rootOfUnity.SetString("32863578547254505029601261939868325669770508939375122462904745766352256812585773382134936404344547323199885654433")
+		maxOrderRoot = 46
+	{{end}}
+	return rootOfUnity, maxOrderRoot
+}
+
+// generatorOfOrder returns a field element of order exactly x, x a power of
+// two, derived from the same 2-adic tower as NewDomain, without building a
+// full Domain (and paying for preComputeTwiddles) just to read off its
+// Generator.
+func generatorOfOrder(x uint) fr.Element {
+	rootOfUnity, maxOrderRoot := rootOfUnityTower()
+
+	logx := uint(bits.TrailingZeros(x))
+	if logx > maxOrderRoot-1 {
+		panic("x is too big: the required root of unity does not exist")
+	}
+	expo := uint64(1 << (maxOrderRoot - logx - 1))
+
+	var g fr.Element
+	g.Exp(rootOfUnity, new(big.Int).SetUint64(expo))
+	g.Mul(&g, &g) // order x
+	return g
+}
+
 func nextPowerOfTwo(n uint) uint {
 	p := uint(1)
 	if (n & (n - 1)) == 0 {
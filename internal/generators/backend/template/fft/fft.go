@@ -0,0 +1,58 @@
+package fft
+
+// FFTCore ...
+const FFTCore = `
+
+// FFT computes the discrete Fourier transform of a in place, interpreted as
+// the coefficients (or, when inverse is true, the evaluations) of a
+// polynomial over the subgroup of order domain.Cardinality generated by
+// domain.Generator. Domains built by NewDomainAnySize dispatch to the
+// Bluestein chirp-Z path instead, on a slice of domain.bluesteinN elements
+// rather than domain.Cardinality.
+func (domain *Domain) FFT(a []fr.Element, inverse bool) {
+	if domain.bluesteinN != 0 {
+		domain.fftAnySize(a, inverse)
+		return
+	}
+	domain.plainFFT(a, inverse)
+}
+
+// IFFT is the inverse of FFT.
+func (domain *Domain) IFFT(a []fr.Element) {
+	domain.FFT(a, true)
+}
+
+// plainFFT is the ordinary power-of-two Cooley-Tukey transform on a slice of
+// domain.Cardinality elements. It is also what fftAnySize falls back to
+// internally to run the convolutions behind Bluestein's algorithm, since
+// those convolutions are themselves plain power-of-two transforms on the
+// padded domain -- calling FFT there would just bounce back into
+// fftAnySize given domain.bluesteinN is already set.
+func (domain *Domain) plainFFT(a []fr.Element, inverse bool) {
+	domain.ensurePrecomputed()
+
+	twiddles := domain.Twiddles
+	if inverse {
+		twiddles = domain.TwiddlesInv
+	}
+
+	BitReverse(a)
+	if domain.useRadix4() {
+		// fftRadix4 folds two radix-2 stages into one radix-4 level, so it
+		// consumes the same per-stage tables as fftRecursiveHelper, just two
+		// at a time instead of one.
+		fftRadix4(a, twiddles)
+	} else {
+		// fftCacheOblivious recurses down to cacheObliviousThreshold for
+		// locality, then falls back to fftRecursiveHelper for the base case.
+		fftCacheOblivious(a, twiddles)
+	}
+
+	if inverse {
+		for i := 0; i < len(a); i++ {
+			a[i].Mul(&a[i], &domain.CardinalityInv)
+		}
+	}
+}
+
+`
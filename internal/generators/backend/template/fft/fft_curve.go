@@ -0,0 +1,150 @@
+package fft
+
+// FFTCurve ...
+const FFTCurve = `
+
+// FFTG1 computes the discrete Fourier transform (or its inverse) of points,
+// seen as the coefficients (or, for the inverse, the evaluations) of a polynomial with coefficients
+// in G1, over the subgroup of order domain.Cardinality generated by
+// domain.Generator. It reuses the field twiddle factors already pre-computed
+// in domain and mirrors the recursive Cooley-Tukey structure of the field
+// FFT, replacing field multiplications by scalar multiplications and field
+// additions by point additions. This is the building block used to take the
+// IFFT of an SRS given in evaluation form, to commit to a polynomial in
+// Lagrange basis without an explicit interpolation step. points is updated
+// in place; the Jacobian<->Affine conversions it costs are paid here once
+// instead of being pushed onto every caller.
+func (domain *Domain) FFTG1(points []curve.G1Affine, inverse bool) {
+
+	domain.ensurePrecomputed()
+
+	twiddles := domain.Twiddles
+	if inverse {
+		twiddles = domain.TwiddlesInv
+	}
+
+	jac := make([]curve.G1Jac, len(points))
+	for i := 0; i < len(points); i++ {
+		jac[i].FromAffine(&points[i])
+	}
+
+	bitReverseG1(jac)
+	fftG1(jac, twiddles)
+
+	if inverse {
+		var invBig big.Int
+		domain.CardinalityInv.ToBigIntRegular(&invBig)
+		for i := 0; i < len(jac); i++ {
+			jac[i].ScalarMultiplication(&jac[i], &invBig)
+		}
+	}
+
+	curve.BatchJacobianToAffineG1(jac, points)
+}
+
+// FFTG2 is the G2 counterpart of FFTG1.
+func (domain *Domain) FFTG2(points []curve.G2Affine, inverse bool) {
+
+	domain.ensurePrecomputed()
+
+	twiddles := domain.Twiddles
+	if inverse {
+		twiddles = domain.TwiddlesInv
+	}
+
+	jac := make([]curve.G2Jac, len(points))
+	for i := 0; i < len(points); i++ {
+		jac[i].FromAffine(&points[i])
+	}
+
+	bitReverseG2(jac)
+	fftG2(jac, twiddles)
+
+	if inverse {
+		var invBig big.Int
+		domain.CardinalityInv.ToBigIntRegular(&invBig)
+		for i := 0; i < len(jac); i++ {
+			jac[i].ScalarMultiplication(&jac[i], &invBig)
+		}
+	}
+
+	curve.BatchJacobianToAffineG2(jac, points)
+}
+
+// fftG1 recursively splits a into its even and odd indexed points (already
+// laid out by bitReverseG1) and combines the two half-size transforms with
+// the twiddle factors for the current stage, scalar-multiplying the odd half
+// before adding/subtracting it from the even half.
+func fftG1(a []curve.G1Jac, twiddles [][]fr.Element) {
+	n := len(a)
+	if n == 1 {
+		return
+	}
+	m := n >> 1
+
+	fftG1(a[:m], twiddles[1:])
+	fftG1(a[m:], twiddles[1:])
+
+	var tExpo big.Int
+	for i := 0; i < m; i++ {
+		twiddles[0][i].ToBigIntRegular(&tExpo)
+
+		var t curve.G1Jac
+		t.ScalarMultiplication(&a[m+i], &tExpo)
+
+		a[m+i].Set(&a[i])
+		a[m+i].SubAssign(&t)
+		a[i].AddAssign(&t)
+	}
+}
+
+// fftG2 is the G2 counterpart of fftG1.
+func fftG2(a []curve.G2Jac, twiddles [][]fr.Element) {
+	n := len(a)
+	if n == 1 {
+		return
+	}
+	m := n >> 1
+
+	fftG2(a[:m], twiddles[1:])
+	fftG2(a[m:], twiddles[1:])
+
+	var tExpo big.Int
+	for i := 0; i < m; i++ {
+		twiddles[0][i].ToBigIntRegular(&tExpo)
+
+		var t curve.G2Jac
+		t.ScalarMultiplication(&a[m+i], &tExpo)
+
+		a[m+i].Set(&a[i])
+		a[m+i].SubAssign(&t)
+		a[i].AddAssign(&t)
+	}
+}
+
+// bitReverseG1 permutes a in place so that a[i] and a[bitReverse(i)] are
+// swapped, mirroring BitReverse for []fr.Element.
+func bitReverseG1(a []curve.G1Jac) {
+	n := uint(len(a))
+	nn := uint(bits.UintSize - bits.TrailingZeros(n))
+	for i := uint(0); i < n; i++ {
+		irev := bits.Reverse(i) >> nn
+		if irev > i {
+			a[i], a[irev] = a[irev], a[i]
+		}
+	}
+}
+
+// bitReverseG2 is the G2 counterpart of bitReverseG1.
+func bitReverseG2(a []curve.G2Jac) {
+	n := uint(len(a))
+	nn := uint(bits.UintSize - bits.TrailingZeros(n))
+	for i := uint(0); i < n; i++ {
+		irev := bits.Reverse(i) >> nn
+		if irev > i {
+			a[i], a[irev] = a[irev], a[i]
+		}
+	}
+}
+
+`
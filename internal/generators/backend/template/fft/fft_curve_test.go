@@ -0,0 +1,39 @@
+package fft
+
+// FFTCurveTest ...
+const FFTCurveTest = `
+
+import (
+	"testing"
+
+	{{ template "import_curve" . }}
+)
+
+func TestFFTG1RoundTrip(t *testing.T) {
+	domain := NewDomain(8)
+
+	points := make([]curve.G1Affine, domain.Cardinality)
+	_, _, g1Gen, _ := curve.Generators()
+	var acc curve.G1Jac
+	acc.FromAffine(&g1Gen)
+	for i := range points {
+		var p curve.G1Affine
+		p.FromJacobian(&acc)
+		points[i] = p
+		acc.AddAssign(&acc)
+	}
+
+	original := make([]curve.G1Affine, len(points))
+	copy(original, points)
+
+	domain.FFTG1(points, false)
+	domain.FFTG1(points, true)
+
+	for i := range original {
+		if !original[i].Equal(&points[i]) {
+			t.Fatalf("FFTG1/IFFTG1 round trip failed at index %d", i)
+		}
+	}
+}
+
+`
@@ -0,0 +1,100 @@
+package fft
+
+// Radix4 ...
+const Radix4 = `
+
+// radix4Threshold is the minimal, even log2(Cardinality) at which the
+// radix-4 path is worth its extra bookkeeping over plain radix-2 butterflies.
+const radix4Threshold = 4
+
+// useRadix4 reports whether d's cardinality is large enough, and its log2
+// even enough, to run the radix-4 butterfly network instead of radix-2.
+func (d *Domain) useRadix4() bool {
+	logN := bits.TrailingZeros(uint(d.Cardinality))
+	return logN >= radix4Threshold && logN%2 == 0
+}
+
+// fftRadix4 transforms a using radix-4 decimation-in-time butterflies,
+// folding what would otherwise be two nested radix-2 combine stages
+// (fftRecursiveHelper splitting a[:n/2]/a[n/2:], and then again splitting
+// each half) into one radix4Combine pass per level. Quartering a is exactly
+// equivalent to two nested halvings (a[:n/4],a[n/4:n/2] is a[:n/2] split in
+// two, a[n/2:3n/4],a[3n/4:] is a[n/2:] split in two), so this recurses over
+// the same binary-bit-reversed input fftRecursiveHelper expects -- it just
+// consumes twiddles two stages at a time instead of one.
+func fftRadix4(a []fr.Element, twiddles [][]fr.Element) {
+	n := len(a)
+	if n == 1 {
+		return
+	}
+
+	m := n >> 2
+	fftRadix4(a[:m], twiddles[2:])
+	fftRadix4(a[m:2*m], twiddles[2:])
+	fftRadix4(a[2*m:3*m], twiddles[2:])
+	fftRadix4(a[3*m:], twiddles[2:])
+
+	for i := 0; i < m; i++ {
+		radix4Combine(a, i, m, twiddles[0], twiddles[1])
+	}
+}
+
+// radix4Combine merges the four length-m sub-transforms stored at a[i],
+// a[m+i], a[2m+i] and a[3m+i] -- the quarters fftRadix4 just computed --
+// using this level's two stages of twiddles, w0 and w1. It is the direct
+// composition of what two calls to combineRadix2 would do:
+//
+//   - inner stage (the w1-level, shared by both halves): combine (a[i],
+//     a[m+i]) and (a[2m+i], a[3m+i]) using w1[i]
+//   - outer stage (the w0-level): combine the two results above using
+//     w0[i] for the first pair and w0[m+i] for the second -- the radix-4
+//     analogue of complex FFT's w^{n/4} = -i cross-term, since
+//     w0[m+i] == w0[i] * w0[m] and w0[m] is exactly the primitive 4th root
+//     of unity for this level
+func radix4Combine(a []fr.Element, i, m int, w0, w1 []fr.Element) {
+	var t0, t1 fr.Element
+	t0.Mul(&a[m+i], &w1[i])
+	t1.Mul(&a[3*m+i], &w1[i])
+
+	var a0, a1, a2, a3 fr.Element
+	a0.Add(&a[i], &t0)
+	a1.Sub(&a[i], &t0)
+	a2.Add(&a[2*m+i], &t1)
+	a3.Sub(&a[2*m+i], &t1)
+
+	var tAC, tBD fr.Element
+	tAC.Mul(&a2, &w0[i])
+	tBD.Mul(&a3, &w0[m+i])
+
+	a[i].Add(&a0, &tAC)
+	a[2*m+i].Sub(&a0, &tAC)
+	a[m+i].Add(&a1, &tBD)
+	a[3*m+i].Sub(&a1, &tBD)
+}
+
+// cacheObliviousThreshold is the working-set size, in field elements, below
+// which fftCacheOblivious stops recursing and falls back to the plain
+// radix-2 butterfly: past this point the working set fits in L2 and further
+// splitting only adds recursion overhead.
+const cacheObliviousThreshold = 1 << 13
+
+// fftCacheOblivious recursively halves a until its working set fits in L2
+// (cacheObliviousThreshold), improving locality on large domains by
+// scheduling the recursion itself rather than relying on sequential access
+// over the whole vector. Below the threshold it defers to
+// fftRecursiveHelper, which implements the same radix-2 combine step.
+func fftCacheOblivious(a []fr.Element, twiddles [][]fr.Element) {
+	n := len(a)
+	if n <= cacheObliviousThreshold {
+		fftRecursiveHelper(a, twiddles)
+		return
+	}
+
+	m := n >> 1
+	fftCacheOblivious(a[:m], twiddles[1:])
+	fftCacheOblivious(a[m:], twiddles[1:])
+
+	combineRadix2(a, m, twiddles[0])
+}
+
+`
@@ -0,0 +1,55 @@
+package fft
+
+// Radix4Test ...
+const Radix4Test = `
+
+import (
+	"testing"
+
+	{{ template "import_curve" . }}
+)
+
+func TestFFTRadix4RoundTrip(t *testing.T) {
+	// Cardinality 16 has log2 == 4, the smallest size useRadix4 selects, so
+	// this exercises the radix-4 path against the same round-trip check
+	// every other Domain size goes through.
+	domain := NewDomain(16)
+	if !domain.useRadix4() {
+		t.Fatalf("expected useRadix4() to be true for Cardinality=16")
+	}
+
+	a := make([]fr.Element, domain.Cardinality)
+	for i := range a {
+		a[i].SetUint64(uint64(i + 1))
+	}
+
+	b := make([]fr.Element, len(a))
+	copy(b, a)
+	domain.FFT(b, false)
+	domain.FFT(b, true)
+
+	for i := range a {
+		if !a[i].Equal(&b[i]) {
+			t.Fatalf("radix-4 FFT/IFFT round trip failed at index %d", i)
+		}
+	}
+}
+
+func benchmarkFFT(b *testing.B, logSize int) {
+	domain := NewDomain(1 << uint(logSize))
+	a := make([]fr.Element, domain.Cardinality)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		domain.FFT(a, false)
+	}
+}
+
+// BenchmarkFFT16/.../BenchmarkFFT20 exercise the radix-4 dispatch
+// (useRadix4 selects it whenever log2(Cardinality) is even and >= 4) against
+// the same sizes on BN256, BLS381 and BW761, since each curve generates its
+// own copy of this package from the same template.
+func BenchmarkFFT16(b *testing.B) { benchmarkFFT(b, 16) }
+func BenchmarkFFT18(b *testing.B) { benchmarkFFT(b, 18) }
+func BenchmarkFFT20(b *testing.B) { benchmarkFFT(b, 20) }
+
+`
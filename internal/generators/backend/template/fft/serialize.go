@@ -0,0 +1,78 @@
+package fft
+
+// Serialize ...
+const Serialize = `
+
+// WriteTo persists only the fields a Domain cannot re-derive on its own:
+// Generator, GeneratorSqRt and Cardinality. GeneratorInv, GeneratorSqRtInv,
+// CardinalityInv and the Twiddles/ExpTable* tables are not written; they are
+// rebuilt by ensurePrecomputed on first use after ReadFrom.
+func (d *Domain) WriteTo(w io.Writer) (int64, error) {
+	var written int64
+
+	n, err := d.Generator.WriteTo(w)
+	written += n
+	if err != nil {
+		return written, err
+	}
+
+	n, err = d.GeneratorSqRt.WriteTo(w)
+	written += n
+	if err != nil {
+		return written, err
+	}
+
+	var cardinality fr.Element
+	cardinality.SetUint64(uint64(d.Cardinality))
+	n, err = cardinality.WriteTo(w)
+	written += n
+	if err != nil {
+		return written, err
+	}
+
+	return written, nil
+}
+
+// ReadFrom reads a Domain written by WriteTo and re-derives GeneratorInv,
+// GeneratorSqRtInv and CardinalityInv from it. The pre-computed
+// Twiddles/TwiddlesInv/ExpTable1/ExpTable2 tables are left empty; they are
+// rebuilt lazily, guarded by precomputeOnce, the first time the Domain is
+// used to run an FFT. This lets a prover/verifier cache a trusted setup
+// domain to disk and skip preComputeTwiddles, which dominates cold-start
+// time for large Cardinality, on every run that never actually needs it.
+func (d *Domain) ReadFrom(r io.Reader) (int64, error) {
+	var read int64
+
+	n, err := d.Generator.ReadFrom(r)
+	read += n
+	if err != nil {
+		return read, err
+	}
+
+	n, err = d.GeneratorSqRt.ReadFrom(r)
+	read += n
+	if err != nil {
+		return read, err
+	}
+
+	var cardinality fr.Element
+	n, err = cardinality.ReadFrom(r)
+	read += n
+	if err != nil {
+		return read, err
+	}
+
+	var cardinalityBig big.Int
+	cardinality.ToBigIntRegular(&cardinalityBig)
+	d.Cardinality = int(cardinalityBig.Uint64())
+
+	d.GeneratorInv.Inverse(&d.Generator)
+	d.GeneratorSqRtInv.Inverse(&d.GeneratorSqRt)
+	d.CardinalityInv.SetUint64(uint64(d.Cardinality)).Inverse(&d.CardinalityInv)
+
+	d.precomputeOnce = sync.Once{}
+
+	return read, nil
+}
+
+`
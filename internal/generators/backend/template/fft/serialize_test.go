@@ -0,0 +1,45 @@
+package fft
+
+// SerializeTest ...
+const SerializeTest = `
+
+import (
+	"bytes"
+	"testing"
+
+	{{ template "import_curve" . }}
+)
+
+func TestDomainReadFromRebuildsTables(t *testing.T) {
+	domain := NewDomain(8)
+
+	var buf bytes.Buffer
+	if _, err := domain.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	restored := &Domain{}
+	if _, err := restored.ReadFrom(&buf); err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+
+	a := make([]fr.Element, restored.Cardinality)
+	for i := range a {
+		a[i].SetUint64(uint64(i + 1))
+	}
+	b := make([]fr.Element, len(a))
+	copy(b, a)
+
+	// restored.Twiddles/ExpTable1/ExpTable2 are nil at this point; FFT must
+	// call ensurePrecomputed to rebuild them instead of panicking.
+	restored.FFT(b, false)
+	restored.FFT(b, true)
+
+	for i := range a {
+		if !a[i].Equal(&b[i]) {
+			t.Fatalf("FFT/IFFT round trip on restored domain failed at index %d", i)
+		}
+	}
+}
+
+`
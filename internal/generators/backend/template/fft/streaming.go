@@ -0,0 +1,224 @@
+package fft
+
+// Streaming ...
+const Streaming = `
+
+// DomainStreaming is a six-step / four-step FFT domain for cardinalities
+// that are too large to pre-compute full Twiddles, ExpTable1 and ExpTable2
+// tables for (BW761, 2^25+). Instead of the triangular twiddle tables built
+// by preComputeTwiddles, it only keeps Generator and derives the twiddle
+// factor w^{ij} it needs on the fly, and it processes the input one
+// n1-sized row/column block at a time so the full evaluation vector never
+// needs to be resident at once.
+type DomainStreaming struct {
+	Generator      fr.Element
+	GeneratorInv   fr.Element
+	Cardinality    int
+	CardinalityInv fr.Element
+
+	// n1 * n2 == Cardinality; n1 is the column FFT size, n2 the row FFT
+	// size. NewDomainStreaming always derives n1 == n2 (rounding Cardinality
+	// up to an even log2 if needed) so FFT's closing transpose is a plain
+	// square-matrix swap.
+	n1 int
+	n2 int
+}
+
+// NewDomainStreaming returns a DomainStreaming able to transform a vector of
+// m elements (rounded up to the next power of two, and doubled once more if
+// that power has an odd log2) without holding the
+// twiddle tables that NewDomain pre-computes, factoring the cardinality as
+// n = n1 * n2 with n1, n2 as close to sqrt(n) as the power-of-two
+// constraint allows. Unlike NewDomain, it never calls preComputeTwiddles:
+// for BW761 at 2^25+ that precomputation is exactly the in-core cost this
+// type exists to avoid, so Generator/GeneratorInv/Cardinality/CardinalityInv
+// are derived directly from the same 2-adic tower instead.
+func NewDomainStreaming(m int) *DomainStreaming {
+	cardinality := nextPowerOfTwo(uint(m))
+	logN := bits.TrailingZeros(cardinality)
+	if logN%2 != 0 {
+		// force an even log2 so n1 == n2 == sqrt(cardinality): the final
+		// transpose in FFT below only needs a plain in-place square-matrix
+		// swap this way, rather than a reshape across mismatched strides.
+		cardinality <<= 1
+		logN++
+	}
+	generator := generatorOfOrder(cardinality)
+
+	var generatorInv, cardinalityInv fr.Element
+	generatorInv.Inverse(&generator)
+	cardinalityInv.SetUint64(uint64(cardinality)).Inverse(&cardinalityInv)
+
+	log1 := logN / 2
+	log2 := logN - log1
+
+	return &DomainStreaming{
+		Generator:      generator,
+		GeneratorInv:   generatorInv,
+		Cardinality:    int(cardinality),
+		CardinalityInv: cardinalityInv,
+		n1:             1 << uint(log1),
+		n2:             1 << uint(log2),
+	}
+}
+
+// BlockStore is the n1 x n2 matrix DomainStreaming.FFT operates on. Going
+// through Get/Set instead of a resident []fr.Element slice is what makes
+// the transform out-of-core: FFT only ever materializes one column
+// (n1 elements) or one row (n2 elements) of it at a time, so a BlockStore
+// backed by an mmap'ed file never needs the full n1*n2 vector in RAM.
+type BlockStore interface {
+	Get(i, j int) fr.Element
+	Set(i, j int, v fr.Element)
+}
+
+// SliceBlockStore is a BlockStore backed by a single in-memory, row-major
+// slice of length n1*n2; it is what NewDomain-based code would have held
+// resident anyway, provided here for tests and for domains small enough
+// that out-of-core storage isn't worth the trouble.
+type SliceBlockStore struct {
+	N2 int
+	A  []fr.Element
+}
+
+func (s *SliceBlockStore) Get(i, j int) fr.Element    { return s.A[i*s.N2+j] }
+func (s *SliceBlockStore) Set(i, j int, v fr.Element) { s.A[i*s.N2+j] = v }
+
+// FFT runs the six-step FFT against store, an n1 x n2 matrix: FFT each of
+// the n2 columns (size n1), multiply by the twiddle factors w^{ij}, FFT
+// each of the n1 rows (size n2), then transpose. That last transpose is
+// what turns the row-major output of the row pass -- which otherwise sits
+// at store[k1][k2], the transpose of the result's true position -- into
+// the same element order Domain.FFT produces. Every pass only reads and
+// writes one column, row or (for the transpose) element pair at a time, so
+// store is the only thing that ever needs to hold the full n1*n2 elements
+// -- and when store is backed by an mmap'ed file rather than
+// SliceBlockStore, this process never does.
+func (d *DomainStreaming) FFT(store BlockStore, inverse bool) {
+
+	w := d.Generator
+	if inverse {
+		w = d.GeneratorInv
+	}
+
+	n1, n2 := d.n1, d.n2
+
+	// step 1: n2 FFTs of size n1 on columns
+	col := make([]fr.Element, n1)
+	colTwiddles := d.twiddlesFor(n1, w)
+	for j := 0; j < n2; j++ {
+		for i := 0; i < n1; i++ {
+			col[i] = store.Get(i, j)
+		}
+		fftRecursive(col, colTwiddles)
+		for i := 0; i < n1; i++ {
+			store.Set(i, j, col[i])
+		}
+	}
+
+	// step 2: multiply by the twiddle factors w^{ij}
+	var wij, wi fr.Element
+	for i := 0; i < n1; i++ {
+		wi.Exp(w, new(big.Int).SetUint64(uint64(i)))
+		wij.SetOne()
+		for j := 0; j < n2; j++ {
+			v := store.Get(i, j)
+			v.Mul(&v, &wij)
+			store.Set(i, j, v)
+			wij.Mul(&wij, &wi)
+		}
+	}
+
+	// step 3/4: n1 FFTs of size n2 on rows
+	row := make([]fr.Element, n2)
+	rowTwiddles := d.twiddlesFor(n2, w)
+	for i := 0; i < n1; i++ {
+		for j := 0; j < n2; j++ {
+			row[j] = store.Get(i, j)
+		}
+		fftRecursive(row, rowTwiddles)
+		if inverse {
+			for j := 0; j < n2; j++ {
+				row[j].Mul(&row[j], &d.CardinalityInv)
+			}
+		}
+		for j := 0; j < n2; j++ {
+			store.Set(i, j, row[j])
+		}
+	}
+
+	// step 5: transpose. The row pass above leaves the k1-th row holding
+	// the k2-th frequency at store[k1][k2], but the six-step algorithm's
+	// output index is k1 + n1*k2 -- i.e. it belongs at store[k2][k1], since
+	// n1 == n2 makes that the same flat position. Swapping every
+	// off-diagonal pair once puts every element where it belongs.
+	for i := 0; i < n1; i++ {
+		for j := i + 1; j < n2; j++ {
+			vij := store.Get(i, j)
+			vji := store.Get(j, i)
+			store.Set(i, j, vji)
+			store.Set(j, i, vij)
+		}
+	}
+}
+
+// twiddlesFor builds, on the fly, the triangular twiddle table preComputeTwiddles
+// would have stored for a power-of-two size n and base root w, without
+// retaining it once the caller is done with it.
+func (d *DomainStreaming) twiddlesFor(n int, w fr.Element) [][]fr.Element {
+	nbStages := uint(bits.TrailingZeros(uint(n)))
+	t := make([][]fr.Element, nbStages)
+
+	var stageRoot fr.Element
+	stageRoot.Exp(w, new(big.Int).SetUint64(uint64(d.Cardinality/n)))
+
+	cur := stageRoot
+	for i := uint(0); i < nbStages; i++ {
+		size := 1 + (1 << (nbStages - i))
+		t[i] = make([]fr.Element, size)
+		t[i][0].SetOne()
+		for j := 1; j < size; j++ {
+			t[i][j].Mul(&t[i][j-1], &cur)
+		}
+		cur.Mul(&cur, &cur)
+	}
+	return t
+}
+
+// fftRecursive is the same bit-reversed, recursive Cooley-Tukey butterfly
+// used by the in-core Domain, parameterized by an explicit twiddle table so
+// the streaming path can run it on its on-the-fly tables. The in-core
+// plainFFT does not call it; it bit-reverses a itself and reaches
+// fftRecursiveHelper through fftCacheOblivious.
+func fftRecursive(a []fr.Element, twiddles [][]fr.Element) {
+	BitReverse(a)
+	fftRecursiveHelper(a, twiddles)
+}
+
+func fftRecursiveHelper(a []fr.Element, twiddles [][]fr.Element) {
+	n := len(a)
+	if n == 1 {
+		return
+	}
+	m := n >> 1
+
+	fftRecursiveHelper(a[:m], twiddles[1:])
+	fftRecursiveHelper(a[m:], twiddles[1:])
+
+	combineRadix2(a, m, twiddles[0])
+}
+
+// combineRadix2 merges the two length-m transforms stored at a[:m] and
+// a[m:] using the twiddle factors w for this stage; shared by every
+// radix-2 recursion in this package (fftRecursiveHelper, fftCacheOblivious)
+// so the Cooley-Tukey combine math only lives in one place.
+func combineRadix2(a []fr.Element, m int, w []fr.Element) {
+	var t fr.Element
+	for i := 0; i < m; i++ {
+		t.Mul(&a[m+i], &w[i])
+		a[m+i].Sub(&a[i], &t)
+		a[i].Add(&a[i], &t)
+	}
+}
+
+`
@@ -0,0 +1,69 @@
+package fft
+
+// StreamingTest ...
+const StreamingTest = `
+
+import (
+	"testing"
+
+	{{ template "import_curve" . }}
+)
+
+func TestDomainStreamingMatchesDomain(t *testing.T) {
+	m := 64
+	domain := NewDomain(m)
+	streaming := NewDomainStreaming(m)
+
+	a := make([]fr.Element, domain.Cardinality)
+	for i := range a {
+		a[i].SetUint64(uint64(i + 1))
+	}
+
+	want := make([]fr.Element, len(a))
+	copy(want, a)
+	domain.FFT(want, false)
+
+	store := &SliceBlockStore{N2: streaming.n2, A: make([]fr.Element, len(a))}
+	copy(store.A, a)
+	streaming.FFT(store, false)
+
+	for i := range want {
+		if !want[i].Equal(&store.A[i]) {
+			t.Fatalf("DomainStreaming.FFT disagrees with Domain.FFT at index %d", i)
+		}
+	}
+}
+
+func benchmarkSize(b *testing.B, size int, streaming bool) {
+	if streaming {
+		d := NewDomainStreaming(size)
+		store := &SliceBlockStore{N2: d.n2, A: make([]fr.Element, d.Cardinality)}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			d.FFT(store, false)
+		}
+		return
+	}
+
+	d := NewDomain(size)
+	a := make([]fr.Element, d.Cardinality)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.FFT(a, false)
+	}
+}
+
+// BenchmarkDomainFFT and BenchmarkDomainStreamingFFT are meant to be run
+// with -benchmem (and, to compare peak RSS rather than just time, under
+// /usr/bin/time -v or a pprof heap profile): DomainStreaming trades some
+// wall-clock time for never holding the full Twiddles/ExpTable1/ExpTable2
+// tables or evaluation vector that NewDomain's in-core path keeps resident.
+func BenchmarkDomainFFT(b *testing.B) {
+	benchmarkSize(b, 1<<16, false)
+}
+
+func BenchmarkDomainStreamingFFT(b *testing.B) {
+	benchmarkSize(b, 1<<16, true)
+}
+
+`